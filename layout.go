@@ -0,0 +1,138 @@
+package tuesday
+
+import "strings"
+
+// zoneLayouts maps the %z colon count to the equivalent Go reference-time
+// zone fragment, mirroring formatZone in strftime.go.
+var zoneLayouts = map[int]string{
+	0: "-0700",
+	1: "-07:00",
+}
+
+// Layout translates format, a Ruby/POSIX strftime format string, into an
+// equivalent Go reference-time layout suitable for time.Format or
+// time.Parse. It reads from the same directives table Strftime uses, so a
+// layout it reports is guaranteed to render identically to
+// StrftimeInLocale(format, t, "en_US"); Layout isn't locale-aware.
+//
+// ok is false when format contains a directive, flag or width Go's layout
+// language can't express (for example %N/%L that don't follow %S and a '.'
+// or ',', %j, %U, %V, %W, %s, %::z or %^B), or literal text Go's layout
+// language would read as a reference-time element. Callers should use the returned
+// layout with time.Format when ok is true, and
+// fall back to Strftime otherwise.
+func Layout(format string) (layout string, ok bool) {
+	format = expand(format)
+	runes := []rune(format)
+
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			// Go's layout parser would read these as (part of) a
+			// reference-time element rather than as literal text.
+			if strings.ContainsRune("0123456789JMPpZ_", runes[i]) {
+				return "", false
+			}
+			if l := b.String(); (runes[i] == 'd' && strings.HasSuffix(l, "Mon")) || (runes[i] == 'u' && strings.HasSuffix(l, "Jan")) {
+				return "", false
+			}
+			b.WriteRune(runes[i])
+			continue
+		}
+		i++
+		if i >= len(runes) {
+			return "", false
+		}
+
+		spec, next, err := scanConversion(format, runes, i)
+		if err != nil {
+			return "", false
+		}
+		i = next - 1
+
+		if spec.colons > 0 {
+			frag, ok := zoneLayouts[spec.colons]
+			if !ok {
+				return "", false
+			}
+			b.WriteString(frag)
+			continue
+		}
+
+		switch spec.conv {
+		case '%':
+			b.WriteByte('%')
+			continue
+		case 'n':
+			b.WriteByte('\n')
+			continue
+		case 't':
+			b.WriteByte('\t')
+			continue
+		}
+
+		d, known := directives[byte(spec.conv)]
+		if !known || spec.conv >= 0x80 || !d.goLayoutOK || spec.upper {
+			return "", false
+		}
+		if spec.width >= 0 && spec.width != d.defaultWidth {
+			return "", false
+		}
+		if spec.pad != 0 && spec.pad != padNone && spec.pad != d.defaultPad {
+			return "", false
+		}
+		if spec.pad == padNone {
+			return "", false
+		}
+		b.WriteString(d.goLayout)
+
+		if spec.conv == 'S' {
+			if frac, consumedTo, ok := fractionalSecondsLayout(runes, next); ok {
+				b.WriteString(frac)
+				i = consumedTo - 1
+			}
+		}
+	}
+	return b.String(), true
+}
+
+// fractionalSecondsLayout recognises a literal '.' or ',' followed by a
+// plain (unflagged) %N or %L directly after %S, the only shape Go's
+// reference layout can express fractional seconds in, and returns the
+// equivalent "._000000000"-style fragment plus the index past it. %N/%L
+// elsewhere (not immediately after %S) has no Go layout equivalent.
+func fractionalSecondsLayout(runes []rune, i int) (layout string, consumedTo int, ok bool) {
+	if i >= len(runes) || (runes[i] != '.' && runes[i] != ',') {
+		return "", 0, false
+	}
+	sep := runes[i]
+	i++
+	if i >= len(runes) || runes[i] != '%' {
+		return "", 0, false
+	}
+	i++
+	if i >= len(runes) {
+		return "", 0, false
+	}
+	spec, next, err := scanConversion(string(runes), runes, i)
+	if err != nil || spec.pad != 0 || spec.upper {
+		return "", 0, false
+	}
+	digits := spec.width
+	switch spec.conv {
+	case 'N':
+		if digits < 0 {
+			digits = 9
+		}
+	case 'L':
+		if digits < 0 {
+			digits = 3
+		}
+	default:
+		return "", 0, false
+	}
+	if digits < 1 || digits > 9 {
+		return "", 0, false
+	}
+	return string(sep) + strings.Repeat("0", digits), next, true
+}
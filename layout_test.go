@@ -0,0 +1,89 @@
+package tuesday
+
+import (
+	"testing"
+	"time"
+)
+
+var layoutTests = []struct {
+	format string
+	layout string
+	ok     bool
+}{
+	{"%Y-%m-%dT%H:%M:%S%z", "2006-01-02T15:04:05-0700", true},
+	{"%F", "2006-01-02", true},
+	{"%D", "01/02/06", true},
+	{"%v", "_2-Jan-2006", true},
+	{"%a, %d %b %Y %T %Z", "Mon, 02 Jan 2006 15:04:05 MST", true},
+	{"%Y-%m-%dT%H:%M:%S%:z", "2006-01-02T15:04:05-07:00", true},
+	{"%Y-%m-%dT%H:%M:%S.%N", "2006-01-02T15:04:05.000000000", true},
+	{"%Y-%m-%dT%H:%M:%S.%9N", "2006-01-02T15:04:05.000000000", true},
+	{"%H:%M:%S.%3N", "15:04:05.000", true},
+	{"%H:%M:%S.%L", "15:04:05.000", true},
+
+	// not expressible as a Go reference layout.
+	{"%3N", "", false},
+	{"%N", "", false},
+	{"%S,%12N", "", false},
+	{"%j", "", false},
+	{"%U", "", false},
+	{"%V", "", false},
+	{"%W", "", false},
+	{"%s", "", false},
+	{"%::z", "", false},
+	{"%^B", "", false},
+}
+
+func TestLayout(t *testing.T) {
+	for _, test := range layoutTests {
+		layout, ok := Layout(test.format)
+		if ok != test.ok {
+			t.Errorf("Layout(%q) ok = %v, want %v", test.format, ok, test.ok)
+			continue
+		}
+		if ok && layout != test.layout {
+			t.Errorf("Layout(%q) = %q, want %q", test.format, layout, test.layout)
+		}
+	}
+}
+
+func TestLayout_matchesStrftime(t *testing.T) {
+	dt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, test := range layoutTests {
+		if !test.ok {
+			continue
+		}
+		layout, ok := Layout(test.format)
+		if !ok {
+			t.Fatalf("Layout(%q) unexpectedly failed", test.format)
+		}
+		want, err := Strftime(test.format, dt)
+		if err != nil {
+			t.Fatalf("Strftime(%q): %v", test.format, err)
+		}
+		if got := dt.Format(layout); got != want {
+			t.Errorf("dt.Format(%q) = %q, want %q (from Strftime)", layout, got, want)
+		}
+	}
+}
+
+func BenchmarkStrftime(b *testing.B) {
+	dt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		if _, err := Strftime("%Y-%m-%dT%H:%M:%S%z", dt); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLayoutFastPath(b *testing.B) {
+	dt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	layout, ok := Layout("%Y-%m-%dT%H:%M:%S%z")
+	if !ok {
+		b.Fatal("format unexpectedly not expressible as a Go layout")
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = dt.Format(layout)
+	}
+}
@@ -0,0 +1,146 @@
+package tuesday
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LocaleData supplies the locale-sensitive strings Strftime needs: month
+// and weekday names, AM/PM markers, and the locale's own expansions for the
+// %c, %x, %X and %r composite directives (themselves ordinary strftime
+// format strings, so they can reorder fields as the locale requires).
+//
+// Months is indexed by time.Month minus one (Months[0] is January), and
+// Weekdays the same way as time.Weekday (Weekdays[0] is Sunday).
+type LocaleData struct {
+	Months       [12]string
+	MonthsAbbrev [12]string
+
+	Weekdays       [7]string
+	WeekdaysAbbrev [7]string
+
+	AM, PM           string // %p
+	AMLower, PMLower string // %P
+
+	DateTime string // %c
+	Date     string // %x
+	Time     string // %X
+	Time12   string // %r
+
+	// Upper, if non-nil, is used instead of strings.ToUpper to implement
+	// %^B/%^A. Locales with case folding strings.ToUpper gets wrong (e.g.
+	// Turkish's dotted/dotless I) can opt in with
+	// golang.org/x/text/cases.Upper(language.Turkish).String.
+	Upper func(string) string
+}
+
+var (
+	localeMu      sync.RWMutex
+	locales       = map[string]LocaleData{}
+	defaultLocale = "en_US"
+)
+
+// RegisterLocale makes data available under name for StrftimeInLocale and
+// SetDefaultLocale. Registering a name that already exists replaces it.
+func RegisterLocale(name string, data LocaleData) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	locales[name] = data
+}
+
+// SetDefaultLocale changes the locale Strftime uses when none is given
+// explicitly. It returns an error if name hasn't been registered.
+func SetDefaultLocale(name string) error {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	if _, ok := locales[name]; !ok {
+		return fmt.Errorf("tuesday: unknown locale %q", name)
+	}
+	defaultLocale = name
+	return nil
+}
+
+func lookupLocale(name string) (LocaleData, error) {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	d, ok := locales[name]
+	if !ok {
+		return LocaleData{}, fmt.Errorf("tuesday: unknown locale %q", name)
+	}
+	return d, nil
+}
+
+func currentDefaultLocale() LocaleData {
+	localeMu.RLock()
+	name := defaultLocale
+	localeMu.RUnlock()
+	d, err := lookupLocale(name)
+	if err != nil {
+		// Can't happen: defaultLocale is only ever set to a name that was
+		// just checked to exist in SetDefaultLocale.
+		panic(err)
+	}
+	return d
+}
+
+func init() {
+	RegisterLocale("en_US", LocaleData{
+		Months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		MonthsAbbrev:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		WeekdaysAbbrev: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		AM:             "AM", PM: "PM", AMLower: "am", PMLower: "pm",
+		DateTime: "%a %b %e %T %Y",
+		Date:     "%m/%d/%y",
+		Time:     "%T",
+		Time12:   "%I:%M:%S %p",
+	})
+
+	RegisterLocale("en_GB", LocaleData{
+		Months:         [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		MonthsAbbrev:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		WeekdaysAbbrev: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		AM:             "AM", PM: "PM", AMLower: "am", PMLower: "pm",
+		DateTime: "%a %d %b %Y %T %Z",
+		Date:     "%d/%m/%Y",
+		Time:     "%T",
+		Time12:   "%T",
+	})
+
+	RegisterLocale("fr_FR", LocaleData{
+		Months:         [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthsAbbrev:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		WeekdaysAbbrev: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		AM:             "", PM: "", AMLower: "", PMLower: "",
+		DateTime: "%a %d %b %Y %T %Z",
+		Date:     "%d/%m/%Y",
+		Time:     "%T",
+		Time12:   "%T",
+	})
+
+	RegisterLocale("de_DE", LocaleData{
+		Months:         [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsAbbrev:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		WeekdaysAbbrev: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		AM:             "", PM: "", AMLower: "", PMLower: "",
+		DateTime: "%a %d %b %Y %T %Z",
+		Date:     "%d.%m.%Y",
+		Time:     "%T",
+		Time12:   "%T",
+	})
+
+	RegisterLocale("ja_JP", LocaleData{
+		Months:         [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		MonthsAbbrev:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:       [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		WeekdaysAbbrev: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		AM:             "午前", PM: "午後", AMLower: "午前", PMLower: "午後",
+		DateTime: "%Y年%m月%d日 %H時%M分%S秒",
+		Date:     "%Y年%m月%d日",
+		Time:     "%H時%M分%S秒",
+		Time12:   "%p%I時%M分",
+	})
+}
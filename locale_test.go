@@ -0,0 +1,91 @@
+package tuesday
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStrftimeInLocale(t *testing.T) {
+	dt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		locale string
+		format string
+		want   string
+	}{
+		{"en_US", "%B %A", "January Monday"},
+		{"fr_FR", "%B %A", "janvier lundi"},
+		{"de_DE", "%B %A", "Januar Montag"},
+		{"ja_JP", "%B %A", "1月 月曜日"},
+		{"en_GB", "%x", "02/01/2006"},
+		{"fr_FR", "%x", "02/01/2006"},
+		{"de_DE", "%x", "02.01.2006"},
+	}
+	for _, test := range tests {
+		got, err := StrftimeInLocale(test.format, dt, test.locale)
+		if err != nil {
+			t.Errorf("StrftimeInLocale(%q, _, %q): %v", test.format, test.locale, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("StrftimeInLocale(%q, _, %q) = %q, want %q", test.format, test.locale, got, test.want)
+		}
+	}
+}
+
+func TestStrftimeInLocale_noAMPM(t *testing.T) {
+	dt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := StrftimeInLocale("%I:%M %p", dt, "fr_FR"); err == nil {
+		t.Error("expected an error formatting the AM/PM marker in a locale that has none")
+	}
+}
+
+func TestStrftimeInLocale_unknown(t *testing.T) {
+	dt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := StrftimeInLocale("%B", dt, "xx_XX"); err == nil {
+		t.Error("expected an error for an unregistered locale")
+	}
+}
+
+func TestSetDefaultLocale(t *testing.T) {
+	dt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	if err := SetDefaultLocale("de_DE"); err != nil {
+		t.Fatalf("SetDefaultLocale: %v", err)
+	}
+	defer SetDefaultLocale("en_US") // nolint: errcheck
+
+	got, err := Strftime("%B", dt)
+	if err != nil {
+		t.Fatalf("Strftime: %v", err)
+	}
+	if got != "Januar" {
+		t.Errorf("Strftime(%%B) = %q, want %q", got, "Januar")
+	}
+
+	if err := SetDefaultLocale("xx_XX"); err == nil {
+		t.Error("expected an error for an unregistered locale")
+	}
+}
+
+func TestRegisterLocale_upperHook(t *testing.T) {
+	calls := 0
+	RegisterLocale("test_upper", LocaleData{
+		Months: [12]string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"},
+		Upper: func(s string) string {
+			calls++
+			return "UPPER(" + s + ")"
+		},
+	})
+
+	dt := time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)
+	got, err := StrftimeInLocale("%^B", dt, "test_upper")
+	if err != nil {
+		t.Fatalf("StrftimeInLocale: %v", err)
+	}
+	if want := "UPPER(jan)"; got != want {
+		t.Errorf("StrftimeInLocale(%%^B) = %q, want %q", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("Upper called %d times, want 1", calls)
+	}
+}
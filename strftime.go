@@ -0,0 +1,476 @@
+// Package tuesday implements Ruby's Time#strftime directives on top of Go's
+// time.Time, so that format strings written for Ruby (or for any other
+// strftime-compatible runtime) produce identical output in Go.
+package tuesday
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// padNone is a sentinel pad byte meaning "never pad, regardless of width"
+// (the %- flag). It can't collide with a real pad character since those are
+// always '0' or ' '.
+const padNone = 1
+
+// directive describes everything the package needs to know about a single
+// strftime conversion: how wide and padded it is by default, and how (if at
+// all) it can be expressed as a Go reference-time layout or a UTS #35/LDML
+// pattern. Strftime, Layout and ToUTS35 all consult this same table so the
+// three never drift out of sync with one another.
+type directive struct {
+	defaultWidth int
+	defaultPad   byte
+
+	// goLayout is the Go reference-time fragment equivalent to this
+	// directive; goLayoutOK is false when no such fragment exists.
+	goLayout   string
+	goLayoutOK bool
+
+	// uts35 is the UTS #35 (LDML) pattern fragment equivalent to this
+	// directive; uts35OK is false when no such fragment exists.
+	uts35   string
+	uts35OK bool
+}
+
+// directives is the shared metadata table described above, keyed by
+// conversion letter.
+var directives = map[byte]directive{
+	'Y': {4, '0', "2006", true, "yyyy", true},
+	'y': {2, '0', "06", true, "yy", true},
+	'C': {2, '0', "", false, "", false},
+	'm': {2, '0', "01", true, "MM", true},
+	'd': {2, '0', "02", true, "dd", true},
+	'e': {2, ' ', "_2", true, "d", true},
+	'j': {3, '0', "", false, "DDD", true},
+	'H': {2, '0', "15", true, "HH", true},
+	'k': {2, ' ', "", false, "H", false},
+	'I': {2, '0', "03", true, "hh", true},
+	'l': {2, ' ', "", false, "h", false},
+	'M': {2, '0', "04", true, "mm", true},
+	'S': {2, '0', "05", true, "ss", true},
+	'p': {2, '0', "PM", true, "a", true},
+	'P': {2, '0', "pm", true, "", false},
+	'u': {1, '0', "", false, "", false},
+	'w': {1, '0', "", false, "", false},
+	'U': {2, '0', "", false, "", false},
+	'V': {2, '0', "", false, "ww", true},
+	'W': {2, '0', "", false, "", false},
+	'A': {0, 0, "Monday", true, "EEEE", true},
+	'a': {0, 0, "Mon", true, "E", true},
+	'B': {0, 0, "January", true, "MMMM", true},
+	'b': {0, 0, "Jan", true, "MMM", true},
+	'h': {0, 0, "Jan", true, "MMM", true},
+	'z': {0, 0, "-0700", true, "Z", true},
+	'Z': {0, 0, "MST", true, "zzz", true},
+	'n': {0, 0, "\n", true, "\n", false},
+	't': {0, 0, "\t", true, "\t", false},
+}
+
+// expansions lists the composite directives that are purely shorthand for a
+// sequence of other directives. They're substituted textually before the
+// main scan, so the rest of the package only ever has to deal with the
+// directives above (plus %N/%L/%Q/%s and the timezone colon variants, which
+// aren't expressible as plain substitution).
+// %c, %x, %X and %r vary by locale: the entries below are their English
+// defaults, which Layout and ToUTS35 (not locale-aware) use directly, and
+// which expandLocale replaces with the locale's own expansions.
+var expansions = map[byte]string{
+	'+': "%a %b %e %T %Z %Y",
+	'F': "%Y-%m-%d",
+	'D': "%m/%d/%y",
+	'R': "%H:%M",
+	'T': "%H:%M:%S",
+	'v': "%e-%b-%Y",
+	'c': "%a %b %e %T %Y",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+	'r': "%I:%M:%S %p",
+}
+
+// expand repeatedly substitutes composite directives until none remain,
+// guarding against runaway recursion (none of the built-in expansions are
+// self-referential, but a future one might be). It always uses the English
+// defaults for %c/%x/%X/%r; Strftime goes through expandLocale instead so
+// those four can vary by locale.
+func expand(format string) string {
+	return expandWith(format, expansions)
+}
+
+// expandLocale is expand, but with %c/%x/%X/%r drawn from loc instead of
+// the English defaults.
+func expandLocale(format string, loc LocaleData) string {
+	table := make(map[byte]string, len(expansions))
+	for k, v := range expansions {
+		table[k] = v
+	}
+	table['c'], table['x'], table['X'], table['r'] = loc.DateTime, loc.Date, loc.Time, loc.Time12
+	return expandWith(format, table)
+}
+
+func expandWith(format string, table map[byte]string) string {
+	for pass := 0; pass < 8; pass++ {
+		changed := false
+		var b strings.Builder
+		for i := 0; i < len(format); i++ {
+			if format[i] != '%' || i+1 >= len(format) {
+				b.WriteByte(format[i])
+				continue
+			}
+			if format[i+1] == '%' {
+				// "%%" is a literal percent; what follows it isn't a directive.
+				b.WriteString("%%")
+				i++
+				continue
+			}
+			if repl, ok := table[format[i+1]]; ok {
+				b.WriteString(repl)
+				i++
+				changed = true
+				continue
+			}
+			b.WriteByte(format[i])
+		}
+		format = b.String()
+		if !changed {
+			break
+		}
+	}
+	return format
+}
+
+var zoneAbbrevOffsets = map[string]int{
+	"UT": 0, "UTC": 0, "GMT": 0,
+	"EST": -5 * 3600, "EDT": -4 * 3600,
+	"CST": -6 * 3600, "CDT": -5 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600,
+	"PST": -8 * 3600, "PDT": -7 * 3600,
+}
+
+// convSpec is a fully-parsed '%' conversion: its flags, explicit width (-1 if
+// none given) and conversion letter. Both Strftime and Strptime scan formats
+// with scanConversion so the two directions agree on syntax.
+type convSpec struct {
+	colons int // leading ':' count, only meaningful for %z
+	pad    byte
+	upper  bool
+	width  int
+	conv   rune
+}
+
+// scanConversion parses the flags/width/letter that follow a '%' at
+// runes[i], returning the spec and the index of the first rune after it.
+func scanConversion(format string, runes []rune, i int) (convSpec, int, error) {
+	var spec convSpec
+	spec.width = -1
+
+	for i < len(runes) && runes[i] == ':' {
+		spec.colons++
+		i++
+	}
+	if spec.colons > 0 {
+		if i >= len(runes) || runes[i] != 'z' {
+			return spec, i, fmt.Errorf("tuesday: %d colons not followed by z in format %q", spec.colons, format)
+		}
+		spec.conv = 'z'
+		return spec, i + 1, nil
+	}
+
+flags:
+	for i < len(runes) {
+		switch runes[i] {
+		case '-':
+			spec.pad = padNone
+		case '_':
+			spec.pad = ' '
+		case '0':
+			spec.pad = '0'
+		case '^':
+			spec.upper = true
+		default:
+			break flags
+		}
+		i++
+	}
+	digitStart := i
+	for i < len(runes) && runes[i] >= '0' && runes[i] <= '9' {
+		i++
+	}
+	if i > digitStart {
+		spec.width, _ = strconv.Atoi(string(runes[digitStart:i]))
+	}
+	if i >= len(runes) {
+		return spec, i, fmt.Errorf("tuesday: dangling flags in format %q", format)
+	}
+	spec.conv = runes[i]
+	return spec, i + 1, nil
+}
+
+// Strftime formats t according to format, which uses the same '%'-directive
+// syntax as Ruby/POSIX strftime (see the package README for the supported
+// directive list). Unknown directives are passed through unchanged, so that
+// round-tripping an unrecognised "%X" never loses information.
+//
+// It formats month/weekday names and AM/PM markers in the package's default
+// locale (en_US unless changed with SetDefaultLocale); use StrftimeInLocale
+// to pick a locale explicitly for one call.
+func Strftime(format string, t time.Time) (string, error) {
+	return strftimeLocale(format, t, currentDefaultLocale())
+}
+
+// StrftimeInLocale is Strftime, but renders month/weekday names, AM/PM
+// markers and the locale-sensitive %c/%x/%X/%r composites using locale
+// instead of the package default. locale must have been registered with
+// RegisterLocale (the package ships en_US, en_GB, fr_FR, de_DE and ja_JP).
+func StrftimeInLocale(format string, t time.Time, locale string) (string, error) {
+	loc, err := lookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return strftimeLocale(format, t, loc)
+}
+
+func strftimeLocale(format string, t time.Time, loc LocaleData) (string, error) {
+	format = expandLocale(format, loc)
+
+	var b strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			b.WriteRune(runes[i])
+			continue
+		}
+		i++
+		if i >= len(runes) {
+			return "", fmt.Errorf("tuesday: trailing %% in format %q", format)
+		}
+
+		spec, next, err := scanConversion(format, runes, i)
+		if err != nil {
+			return "", err
+		}
+
+		if spec.colons > 0 {
+			b.WriteString(formatZone(t, spec.colons))
+			i = next - 1
+			continue
+		}
+
+		switch spec.conv {
+		case '%':
+			b.WriteByte('%')
+		case 'N', 'L':
+			digits := spec.width
+			if spec.conv == 'L' && digits < 0 {
+				digits = 3
+			}
+			if digits < 0 {
+				digits = 9
+			}
+			b.WriteString(formatNanos(t.Nanosecond(), digits))
+		case 'Q':
+			b.WriteString(strconv.FormatInt(t.Unix()*1e6+int64(t.Nanosecond())/1e3, 10))
+		case 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'z':
+			b.WriteString(formatZone(t, 0))
+		default:
+			// A non-ASCII rune is never a directive; byte(spec.conv) would
+			// otherwise truncate e.g. 'Ł' (U+0141) to 'A'.
+			var s string
+			ok := false
+			if spec.conv < 0x80 {
+				s, ok, err = formatDirective(t, byte(spec.conv), spec.width, spec.pad, spec.upper, loc)
+				if err != nil {
+					return "", err
+				}
+			}
+			if !ok {
+				// Pass the directive through with its flags and width intact.
+				b.WriteString(string(runes[i-1 : next]))
+				i = next - 1
+				continue
+			}
+			b.WriteString(s)
+		}
+		i = next - 1
+	}
+	return b.String(), nil
+}
+
+// formatDirective renders the single-letter directives backed by the
+// directives table above, plus the handful (weekday numbers, week numbers,
+// month/day names) whose values don't reduce to a plain integer or constant
+// string. ok is false for a directive tuesday doesn't recognise, so the
+// caller can pass it through verbatim.
+func formatDirective(t time.Time, conv byte, width int, pad byte, upper bool, loc LocaleData) (string, bool, error) {
+	d, known := directives[conv]
+	if !known {
+		return "", false, nil
+	}
+	w, p := d.defaultWidth, d.defaultPad
+	if width >= 0 {
+		w = width
+	}
+	if pad != 0 {
+		p = pad
+	}
+
+	switch conv {
+	case 'Y':
+		return padNum(t.Year(), w, p), true, nil
+	case 'y':
+		return padNum(t.Year()%100, w, p), true, nil
+	case 'C':
+		return padNum(t.Year()/100, w, p), true, nil
+	case 'm':
+		return padNum(int(t.Month()), w, p), true, nil
+	case 'd':
+		return padNum(t.Day(), w, p), true, nil
+	case 'e':
+		return padNum(t.Day(), w, p), true, nil
+	case 'j':
+		return padNum(t.YearDay(), w, p), true, nil
+	case 'H':
+		return padNum(t.Hour(), w, p), true, nil
+	case 'k':
+		return padNum(t.Hour(), w, p), true, nil
+	case 'I':
+		return padNum(hour12(t.Hour()), w, p), true, nil
+	case 'l':
+		return padNum(hour12(t.Hour()), w, p), true, nil
+	case 'M':
+		return padNum(t.Minute(), w, p), true, nil
+	case 'S':
+		return padNum(t.Second(), w, p), true, nil
+	case 'u':
+		wd := int(t.Weekday())
+		if wd == 0 {
+			wd = 7
+		}
+		return padNum(wd, w, p), true, nil
+	case 'w':
+		return padNum(int(t.Weekday()), w, p), true, nil
+	case 'U':
+		return padNum(weekNumberSunday(t), w, p), true, nil
+	case 'V':
+		_, week := t.ISOWeek()
+		return padNum(week, w, p), true, nil
+	case 'W':
+		return padNum(weekNumberMonday(t), w, p), true, nil
+	case 'A':
+		return applyCase(loc.Weekdays[int(t.Weekday())], upper, loc), true, nil
+	case 'a':
+		return applyCase(loc.WeekdaysAbbrev[int(t.Weekday())], upper, loc), true, nil
+	case 'B':
+		return applyCase(loc.Months[int(t.Month())-1], upper, loc), true, nil
+	case 'b', 'h':
+		return applyCase(loc.MonthsAbbrev[int(t.Month())-1], upper, loc), true, nil
+	case 'p':
+		if loc.AM == "" && loc.PM == "" {
+			return "", false, fmt.Errorf("tuesday: locale has no AM/PM marker")
+		}
+		if t.Hour() < 12 {
+			return loc.AM, true, nil
+		}
+		return loc.PM, true, nil
+	case 'P':
+		if loc.AMLower == "" && loc.PMLower == "" {
+			return "", false, fmt.Errorf("tuesday: locale has no AM/PM marker")
+		}
+		if t.Hour() < 12 {
+			return loc.AMLower, true, nil
+		}
+		return loc.PMLower, true, nil
+	case 'Z':
+		name, _ := t.Zone()
+		return name, true, nil
+	case 'n':
+		return "\n", true, nil
+	case 't':
+		return "\t", true, nil
+	}
+	return "", false, fmt.Errorf("tuesday: unhandled directive %%%c", conv)
+}
+
+func hour12(h int) int {
+	h %= 12
+	if h == 0 {
+		h = 12
+	}
+	return h
+}
+
+// weekNumberSunday implements the %U algorithm: the number of Sundays that
+// have occurred so far this year, with everything before the first Sunday
+// counted as week 00.
+func weekNumberSunday(t time.Time) int {
+	return (t.YearDay() + 6 - int(t.Weekday())) / 7
+}
+
+// weekNumberMonday is %U's Monday-first counterpart, used by %W.
+func weekNumberMonday(t time.Time) int {
+	wday := (int(t.Weekday()) + 6) % 7
+	return (t.YearDay() + 6 - wday) / 7
+}
+
+func applyCase(s string, upper bool, loc LocaleData) string {
+	if !upper {
+		return s
+	}
+	if loc.Upper != nil {
+		return loc.Upper(s)
+	}
+	return strings.ToUpper(s)
+}
+
+// padNum renders n as decimal, left-padding with pad until it's at least
+// width characters. A pad of padNone (the %- flag) disables padding
+// entirely, even if width was also given explicitly.
+func padNum(n, width int, pad byte) string {
+	s := strconv.Itoa(n)
+	if pad == padNone {
+		return s
+	}
+	if pad == 0 {
+		pad = '0'
+	}
+	for len(s) < width {
+		s = string(pad) + s
+	}
+	return s
+}
+
+// formatNanos renders nanos as exactly digits decimal places, truncating or
+// zero-extending the 9-digit nanosecond value as needed.
+func formatNanos(nanos, digits int) string {
+	s := fmt.Sprintf("%09d", nanos)
+	switch {
+	case digits <= len(s):
+		return s[:digits]
+	default:
+		return s + strings.Repeat("0", digits-len(s))
+	}
+}
+
+func formatZone(t time.Time, colons int) string {
+	_, offset := t.Zone()
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	h := offset / 3600
+	m := (offset % 3600) / 60
+	s := offset % 60
+	switch colons {
+	case 1:
+		return fmt.Sprintf("%c%02d:%02d", sign, h, m)
+	case 2:
+		return fmt.Sprintf("%c%02d:%02d:%02d", sign, h, m, s)
+	default:
+		return fmt.Sprintf("%c%02d%02d", sign, h, m)
+	}
+}
@@ -0,0 +1,449 @@
+package tuesday
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Strptime parses value according to format, which uses the same strftime
+// directive syntax as Strftime, and returns the resulting time.Time,
+// interpreting value in time.Local when format has no zone (see
+// ParseInLocation). It is the inverse of Strftime: formatting t with format
+// and then parsing the result with Strptime reconstructs t (to the precision
+// the format string preserves). %j, %U, %V, %W, %u, %w, %A and %a are
+// consumed but play no part in the result, so a date carried only by them
+// (e.g. "%Y-%j") is not reconstructed; %s is not supported.
+//
+// Month/weekday names and AM/PM markers are matched against the package's
+// default locale (en_US unless changed with SetDefaultLocale); use
+// StrptimeInLocale to pick a locale explicitly for one call.
+func Strptime(format, value string) (time.Time, error) {
+	return ParseInLocation(format, value, time.Local)
+}
+
+// ParseInLocation is like Strptime, but interprets value in tloc when the
+// format includes no explicit zone (%z/%Z). When the format does include a
+// zone, the parsed offset (or looked-up zone name) takes precedence and
+// tloc is ignored, mirroring time.ParseInLocation.
+func ParseInLocation(format, value string, tloc *time.Location) (time.Time, error) {
+	return parseTime(format, value, tloc, currentDefaultLocale())
+}
+
+// StrptimeInLocale is Strptime, but matches month/weekday names and AM/PM
+// markers against locale instead of the package default.
+func StrptimeInLocale(format, value, locale string) (time.Time, error) {
+	return ParseInLocationWithLocale(format, value, time.Local, locale)
+}
+
+// ParseInLocationWithLocale is ParseInLocation, but matches month/weekday
+// names and AM/PM markers against locale instead of the package default.
+func ParseInLocationWithLocale(format, value string, tloc *time.Location, locale string) (time.Time, error) {
+	ld, err := lookupLocale(locale)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseTime(format, value, tloc, ld)
+}
+
+func parseTime(format, value string, tloc *time.Location, locale LocaleData) (time.Time, error) {
+	format = expandLocale(format, locale)
+	runes := []rune(format)
+
+	st := &parseState{month: 1, day: 1}
+	pos := 0
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			r := runes[i]
+			w, size := utf8.DecodeRuneInString(value[pos:])
+			if size == 0 || w != r {
+				return time.Time{}, fmt.Errorf("tuesday: expected %q at %q", r, value[pos:])
+			}
+			pos += size
+			continue
+		}
+		i++
+		if i >= len(runes) {
+			return time.Time{}, fmt.Errorf("tuesday: trailing %% in format %q", format)
+		}
+
+		spec, next, err := scanConversion(format, runes, i)
+		if err != nil {
+			return time.Time{}, err
+		}
+		i = next - 1
+
+		if spec.colons > 0 {
+			n, newPos, err := parseZoneOffset(value, pos)
+			if err != nil {
+				return time.Time{}, err
+			}
+			st.zoneOffset = &n
+			pos = newPos
+			continue
+		}
+
+		if spec.conv >= 0x80 {
+			return time.Time{}, fmt.Errorf("tuesday: %%%c is not supported by Strptime", spec.conv)
+		}
+		newPos, err := parseDirective(st, byte(spec.conv), spec.width, value, pos, locale)
+		if err != nil {
+			return time.Time{}, err
+		}
+		pos = newPos
+	}
+	if pos != len(value) {
+		return time.Time{}, fmt.Errorf("tuesday: unconsumed input %q", value[pos:])
+	}
+
+	hour := st.hour
+	if st.haveHour12 {
+		hour = st.hour % 12
+		if st.pm {
+			hour += 12
+		}
+	}
+
+	finalLoc := tloc
+	switch {
+	case st.zoneLoc != nil:
+		// %Z named an IANA zone whose offset/abbreviation depends on the
+		// date, which might not have been fully parsed yet when %Z itself
+		// was scanned (e.g. "%Z %Y-%m-%d"). Resolve it now that every
+		// field is known, rather than against whatever was parsed so far.
+		name, offset := time.Date(st.year, time.Month(st.month), st.day, hour, st.minute, st.second, st.nanosecond, st.zoneLoc).Zone()
+		finalLoc = time.FixedZone(name, offset)
+	case st.zoneOffset != nil:
+		name := st.zoneName
+		if name == "" {
+			name = formatZoneName(*st.zoneOffset)
+		}
+		finalLoc = time.FixedZone(name, *st.zoneOffset)
+	}
+	return time.Date(st.year, time.Month(st.month), st.day, hour, st.minute, st.second, st.nanosecond, finalLoc), nil
+}
+
+// parseState accumulates the fields recognised so far while scanning value;
+// it's assembled into a time.Time once the whole format has been consumed.
+type parseState struct {
+	year, month, day     int
+	hour, minute, second int
+	nanosecond           int
+	haveHour12, pm       bool
+	zoneOffset           *int
+	zoneName             string
+	// zoneLoc is set instead of zoneOffset when %Z names an IANA zone not
+	// in zoneAbbrevOffsets: its offset can't be resolved until the whole
+	// date is known, so resolution is deferred to the end of parseTime.
+	zoneLoc *time.Location
+}
+
+// parseDirective consumes the field for conv out of value starting at pos,
+// storing it into st, and returns the new position.
+func parseDirective(st *parseState, conv byte, width int, value string, pos int, locale LocaleData) (int, error) {
+	switch conv {
+	case 'Y':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.year = n
+		return newPos, err
+	case 'y':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), false)
+		if err == nil {
+			if n < 69 {
+				st.year = 2000 + n
+			} else {
+				st.year = 1900 + n
+			}
+		}
+		return newPos, err
+	case 'C':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), false)
+		st.year = n * 100
+		return newPos, err
+	case 'm':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.month = n
+		return newPos, err
+	case 'd', 'e':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.day = n
+		return newPos, err
+	case 'j':
+		_, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		return newPos, err
+	case 'H', 'k':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.hour = n
+		return newPos, err
+	case 'I', 'l':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.hour = n
+		st.haveHour12 = true
+		return newPos, err
+	case 'M':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.minute = n
+		return newPos, err
+	case 'S':
+		n, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		st.second = n
+		return newPos, err
+	case 'N', 'L':
+		digits := width
+		if conv == 'L' && digits < 0 {
+			digits = 3
+		}
+		if digits < 0 {
+			digits = 9
+		}
+		n, newPos, err := parseInt(value, pos, digits, false)
+		if err == nil {
+			st.nanosecond = scaleToNanos(n, newPos-pos)
+		}
+		return newPos, err
+	case 'Q':
+		n, newPos, err := parseInt(value, pos, 20, false)
+		if err == nil {
+			sec := int64(n) / 1e6
+			usec := int64(n) % 1e6
+			t := time.Unix(sec, usec*1e3).UTC()
+			st.year, st.month, st.day = t.Year(), int(t.Month()), t.Day()
+			st.hour, st.minute, st.second, st.nanosecond = t.Hour(), t.Minute(), t.Second(), t.Nanosecond()
+			// %Q fully determines the instant already; anchor it to UTC so
+			// the caller's tloc doesn't get applied on top and shift it.
+			utcOffset := 0
+			st.zoneOffset = &utcOffset
+			st.zoneName = "UTC"
+		}
+		return newPos, err
+	case 'p', 'P':
+		if locale.AM == "" && locale.PM == "" {
+			return pos, fmt.Errorf("tuesday: locale has no AM/PM marker")
+		}
+		word, newPos, ok := matchWord(value, pos, []string{locale.AM, locale.PM, locale.AMLower, locale.PMLower})
+		if !ok {
+			return pos, fmt.Errorf("tuesday: expected AM/PM at %q", value[pos:])
+		}
+		st.pm = strings.EqualFold(word, locale.PM) || strings.EqualFold(word, locale.PMLower)
+		return newPos, nil
+	case 'A', 'a':
+		_, newPos, ok := matchWord(value, pos, append(append([]string{}, locale.Weekdays[:]...), locale.WeekdaysAbbrev[:]...))
+		if !ok {
+			return pos, fmt.Errorf("tuesday: expected weekday name at %q", value[pos:])
+		}
+		return newPos, nil
+	case 'u', 'w':
+		_, newPos, err := parseInt(value, pos, fieldWidth(conv, width), false)
+		return newPos, err
+	case 'U', 'V', 'W':
+		_, newPos, err := parseInt(value, pos, fieldWidth(conv, width), true)
+		return newPos, err
+	case 'B', 'b', 'h':
+		word, newPos, ok := matchWord(value, pos, append(append([]string{}, locale.Months[:]...), locale.MonthsAbbrev[:]...))
+		if !ok {
+			return pos, fmt.Errorf("tuesday: expected month name at %q", value[pos:])
+		}
+		st.month = monthIndex(word, locale)
+		return newPos, nil
+	case 'z':
+		n, newPos, err := parseZoneOffset(value, pos)
+		if err != nil {
+			return pos, err
+		}
+		st.zoneOffset = &n
+		return newPos, nil
+	case 'Z':
+		name, newPos, err := parseZoneName(value, pos)
+		if err != nil {
+			return pos, err
+		}
+		if offset, ok := zoneAbbrevOffsets[name]; ok {
+			st.zoneOffset = &offset
+			st.zoneName = name
+			return newPos, nil
+		}
+		l, lerr := time.LoadLocation(name)
+		if lerr != nil {
+			return pos, fmt.Errorf("tuesday: unknown zone %q", name)
+		}
+		// The offset (and DST-adjusted abbreviation) depends on the date,
+		// which might not be fully parsed yet if %Z appears before the
+		// date fields in the format. Defer resolution to parseTime, once
+		// every field is known.
+		st.zoneLoc = l
+		return newPos, nil
+	case 'n', 't', '%':
+		want := map[byte]rune{'n': '\n', 't': '\t', '%': '%'}[conv]
+		if pos >= len(value) || rune(value[pos]) != want {
+			return pos, fmt.Errorf("tuesday: expected %q at %q", want, value[pos:])
+		}
+		return pos + 1, nil
+	default:
+		return pos, fmt.Errorf("tuesday: %%%c is not supported by Strptime", conv)
+	}
+}
+
+// fieldWidth returns the maximum number of digits parseInt should consume
+// for conv: its natural default width, or the format's explicit width if
+// that's wider. A width only ever pads Strftime's output further out (it
+// never truncates), so the rendered value can have more digits than the
+// default width but never fewer, and Strptime must be able to consume
+// either.
+func fieldWidth(conv byte, width int) int {
+	w := directives[conv].defaultWidth
+	if width > w {
+		w = width
+	}
+	return w
+}
+
+func scaleToNanos(n, digits int) int {
+	for digits < 9 {
+		n *= 10
+		digits++
+	}
+	for digits > 9 {
+		n /= 10
+		digits--
+	}
+	return n
+}
+
+// parseInt reads an optionally space-padded, optionally signed decimal
+// number of up to maxWidth digits starting at pos.
+func parseInt(value string, pos, maxWidth int, allowSign bool) (int, int, error) {
+	start := pos
+	for pos < len(value) && value[pos] == ' ' {
+		pos++
+	}
+	sign := 1
+	if allowSign && pos < len(value) && (value[pos] == '+' || value[pos] == '-') {
+		if value[pos] == '-' {
+			sign = -1
+		}
+		pos++
+	}
+	digitStart := pos
+	for pos < len(value) && pos-digitStart < maxWidth && value[pos] >= '0' && value[pos] <= '9' {
+		pos++
+	}
+	if pos == digitStart {
+		return 0, start, fmt.Errorf("tuesday: expected number at %q", value[start:])
+	}
+	n, err := strconv.Atoi(value[digitStart:pos])
+	if err != nil {
+		return 0, start, err
+	}
+	return sign * n, pos, nil
+}
+
+func monthIndex(word string, locale LocaleData) int {
+	for i, name := range locale.Months {
+		if strings.EqualFold(name, word) || strings.EqualFold(locale.MonthsAbbrev[i], word) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// matchWord finds the longest candidate in names that matches value at pos
+// case-insensitively, so that e.g. "Monday" isn't short-circuited by "Mon".
+func matchWord(value string, pos int, names []string) (string, int, bool) {
+	best := ""
+	for _, name := range names {
+		if len(name) > len(best) && pos+len(name) <= len(value) &&
+			strings.EqualFold(value[pos:pos+len(name)], name) {
+			best = name
+		}
+	}
+	if best == "" {
+		return "", pos, false
+	}
+	return best, pos + len(best), true
+}
+
+// parseZoneName consumes a run of letters (and '/', '_' for IANA names like
+// "America/New_York") for %Z.
+func parseZoneName(value string, pos int) (string, int, error) {
+	start := pos
+	for pos < len(value) {
+		c := value[pos]
+		if c == '+' || c == '-' || c == ' ' {
+			break
+		}
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '/' || c == '_') {
+			break
+		}
+		pos++
+	}
+	if pos == start {
+		return "", pos, fmt.Errorf("tuesday: expected zone name at %q", value[start:])
+	}
+	return value[start:pos], pos, nil
+}
+
+// parseZoneOffset consumes a %z-style offset: a sign, 2-6 digits with
+// optional colons between the hour/minute/second groups, or a bare "Z" for
+// UTC. It returns the offset in seconds east of UTC.
+func parseZoneOffset(value string, pos int) (int, int, error) {
+	if pos < len(value) && (value[pos] == 'Z' || value[pos] == 'z') {
+		return 0, pos + 1, nil
+	}
+	start := pos
+	if pos >= len(value) || (value[pos] != '+' && value[pos] != '-') {
+		return 0, pos, fmt.Errorf("tuesday: expected zone offset at %q", value[start:])
+	}
+	sign := 1
+	if value[pos] == '-' {
+		sign = -1
+	}
+	pos++
+
+	readDigits := func(n int) (int, error) {
+		if pos+n > len(value) {
+			return 0, fmt.Errorf("tuesday: truncated zone offset at %q", value[start:])
+		}
+		v, err := strconv.Atoi(value[pos : pos+n])
+		pos += n
+		return v, err
+	}
+	skipColon := func() {
+		if pos < len(value) && value[pos] == ':' {
+			pos++
+		}
+	}
+
+	h, err := readDigits(2)
+	if err != nil {
+		return 0, pos, err
+	}
+	skipColon()
+	m := 0
+	if pos < len(value) && value[pos] >= '0' && value[pos] <= '9' {
+		m, err = readDigits(2)
+		if err != nil {
+			return 0, pos, err
+		}
+	}
+	skipColon()
+	s := 0
+	if pos < len(value) && value[pos] >= '0' && value[pos] <= '9' {
+		s, err = readDigits(2)
+		if err != nil {
+			return 0, pos, err
+		}
+	}
+	return sign * (h*3600 + m*60 + s), pos, nil
+}
+
+func formatZoneName(offsetSeconds int) string {
+	sign := byte('+')
+	if offsetSeconds < 0 {
+		sign = '-'
+		offsetSeconds = -offsetSeconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offsetSeconds/3600, (offsetSeconds%3600)/60)
+}
@@ -0,0 +1,145 @@
+package tuesday
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// skipRoundTrip lists conversionTests formats Strptime can't be expected to
+// invert: directives tuesday doesn't recognise are passed through verbatim
+// by Strftime (so round-tripping them is a formatter-only guarantee), and
+// Strptime rejects them outright since there's no directive to parse.
+var skipRoundTrip = map[string]bool{
+	"%&": true,
+	"%⌘": true,
+}
+
+// There's no testdata/tests.csv or testdata/skip.csv in this tree (the
+// upstream generator that produces them was never checked in), so unlike
+// TestStrftime this round trip is built directly off the in-file
+// conversionTests/dayOfWeekTests/hourTests tables instead of reading rows
+// out of a CSV fixture.
+func TestStrptime_roundTrip(t *testing.T) {
+	require := func(cond bool, format string, args ...interface{}) {
+		if !cond {
+			t.Fatalf(format, args...)
+		}
+	}
+	require(os.Setenv("TZ", "America/New_York") == nil, "Setenv")
+
+	loc, err := time.LoadLocation("America/New_York")
+	require(err == nil, "LoadLocation: %v", err)
+	dt := timeMustParse(time.RFC3339Nano, "2006-01-02T15:04:05.123456789-05:00")
+
+	for _, test := range conversionTests {
+		if skipRoundTrip[test.format] {
+			continue
+		}
+		s, err := Strftime(test.format, dt)
+		require(err == nil, "Strftime(%q): %v", test.format, err)
+
+		parsed, err := ParseInLocation(test.format, s, loc)
+		require(err == nil, "Strptime(%q, %q): %v", test.format, s, err)
+
+		again, err := Strftime(test.format, parsed)
+		require(err == nil, "Strftime(%q) on round-tripped time: %v", test.format, err)
+		require(again == s, "round trip through %q: got %q, want %q", test.format, again, s)
+	}
+
+	// dayOfWeekTests exercises %A/%a/%u/%w/%U/%V/%W, which are all
+	// functions of the full date, not just the day-of-month fields the
+	// table itself formats. Prefix %Y%m so the parsed date lands on the
+	// same day of the week as the one the table was generated from,
+	// rather than defaulting to year 0.
+	for day, expect := range dayOfWeekTests {
+		format := "%Y%m" + "%%A=%A %%a=%a %%u=%u %%w=%w %%d=%d %%e=%e %%j=%j %%U=%U %%V=%V %%W=%W"
+		dt := time.Date(2006, 1, day+1, 15, 4, 5, 0, time.UTC)
+		s, err := Strftime(format, dt)
+		require(err == nil, "Strftime(%q): %v", format, err)
+		require(s == "200601"+expect, "Strftime(%q) = %q, want %q", format, s, "200601"+expect)
+
+		parsed, err := ParseInLocation(format, s, time.UTC)
+		require(err == nil, "Strptime(%q, %q): %v", format, s, err)
+
+		again, err := Strftime(format, parsed)
+		require(err == nil, "Strftime(%q) on round-tripped time: %v", format, err)
+		require(again == s, "round trip through %q: got %q, want %q", format, again, s)
+	}
+
+	for _, test := range hourTests {
+		format := "%%H=%H %%k=%k %%I=%I %%l=%l %%P=%P %%p=%p"
+		dt := time.Date(2006, 1, 2, test.hour, 4, 5, 0, time.UTC)
+		s, err := Strftime(format, dt)
+		require(err == nil, "Strftime(%q): %v", format, err)
+
+		parsed, err := ParseInLocation(format, s, time.UTC)
+		require(err == nil, "Strptime(%q, %q): %v", format, s, err)
+
+		again, err := Strftime(format, parsed)
+		require(err == nil, "Strftime(%q) on round-tripped time: %v", format, err)
+		require(again == s, "round trip through %q: got %q, want %q", format, again, s)
+	}
+}
+
+// TestStrptime_zoneNameDST covers the %Z time.LoadLocation fallback: the
+// offset must come from the date being parsed, not from wall-clock "now",
+// since the same IANA zone can be in or out of DST depending on which one
+// we're asked about.
+func TestStrptime_zoneNameDST(t *testing.T) {
+	parsed, err := Strptime("%Y-%m-%d %Z", "2006-01-02 America/New_York")
+	if err != nil {
+		t.Fatalf("Strptime: %v", err)
+	}
+	name, offset := parsed.Zone()
+	if name != "EST" || offset != -5*3600 {
+		t.Errorf("Zone() = %q, %d, want %q, %d", name, offset, "EST", -5*3600)
+	}
+
+	parsed, err = Strptime("%Y-%m-%d %Z", "2006-07-02 America/New_York")
+	if err != nil {
+		t.Fatalf("Strptime: %v", err)
+	}
+	name, offset = parsed.Zone()
+	if name != "EDT" || offset != -4*3600 {
+		t.Errorf("Zone() = %q, %d, want %q, %d", name, offset, "EDT", -4*3600)
+	}
+
+	// %Z appearing before the date fields it depends on must resolve
+	// against the full parsed date, not whatever's been parsed so far.
+	parsed, err = Strptime("%Z %Y-%m-%d", "America/New_York 2006-07-02")
+	if err != nil {
+		t.Fatalf("Strptime: %v", err)
+	}
+	name, offset = parsed.Zone()
+	if name != "EDT" || offset != -4*3600 {
+		t.Errorf("Zone() = %q, %d, want %q, %d", name, offset, "EDT", -4*3600)
+	}
+}
+
+func TestStrptime_fractionalSeconds(t *testing.T) {
+	parsed, err := Strptime("%Y-%m-%d %H:%M:%S.%3N", "2006-01-02 15:04:05.123")
+	if err != nil {
+		t.Fatalf("Strptime: %v", err)
+	}
+	if got := parsed.Nanosecond(); got != 123000000 {
+		t.Errorf("Nanosecond() = %d, want 123000000", got)
+	}
+}
+
+func TestStrptime_zoneOffset(t *testing.T) {
+	parsed, err := Strptime("%Y-%m-%dT%H:%M:%S%:z", "2006-01-02T15:04:05-05:00")
+	if err != nil {
+		t.Fatalf("Strptime: %v", err)
+	}
+	_, offset := parsed.Zone()
+	if offset != -5*3600 {
+		t.Errorf("offset = %d, want %d", offset, -5*3600)
+	}
+}
+
+func TestStrptime_mismatch(t *testing.T) {
+	if _, err := Strptime("%Y-%m-%d", "not-a-date"); err == nil {
+		t.Error("expected an error parsing a non-matching value")
+	}
+}
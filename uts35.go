@@ -0,0 +1,118 @@
+package tuesday
+
+import (
+	"fmt"
+	"strings"
+)
+
+// uts35Zone maps the %z colon count to the equivalent UTS #35 zone pattern.
+var uts35Zone = map[int]string{
+	0: "Z",
+	1: "ZZZZZ",
+}
+
+// ToUTS35 converts format, a Ruby/POSIX strftime format string, into a
+// Unicode LDML (UTS #35) date pattern, the format GUI toolkits and native
+// date pickers speak (GTK, NSDateFormatter, ICU) instead of strftime. It
+// reads from the same directives table Strftime and Layout use, so a
+// pattern it returns is guaranteed to describe the same fields Strftime
+// would render in the en_US locale; ToUTS35 isn't locale-aware.
+//
+// Literal runs of the input that contain an ASCII letter or an apostrophe
+// are wrapped in single quotes, with a doubled quote used to escape a
+// literal apostrophe, per the LDML quoting rules; other literal runs
+// (punctuation, spaces) are left bare.
+// err is non-nil when format contains a directive, flag or width with no
+// LDML equivalent.
+func ToUTS35(format string) (pattern string, err error) {
+	format = expand(format)
+	runes := []rune(format)
+
+	var b strings.Builder
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		s := literal.String()
+		literal.Reset()
+		if !needsQuoting(s) {
+			b.WriteString(s)
+			return
+		}
+		b.WriteByte('\'')
+		b.WriteString(strings.ReplaceAll(s, "'", "''"))
+		b.WriteByte('\'')
+	}
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			literal.WriteRune(runes[i])
+			continue
+		}
+		i++
+		if i >= len(runes) {
+			return "", fmt.Errorf("tuesday: trailing %% in format %q", format)
+		}
+
+		spec, next, err := scanConversion(format, runes, i)
+		if err != nil {
+			return "", err
+		}
+		i = next - 1
+
+		if spec.colons > 0 {
+			frag, ok := uts35Zone[spec.colons]
+			if !ok {
+				return "", fmt.Errorf("tuesday: %%%s%c has no UTS #35 equivalent", strings.Repeat(":", spec.colons), 'z')
+			}
+			flushLiteral()
+			b.WriteString(frag)
+			continue
+		}
+
+		switch spec.conv {
+		case '%':
+			literal.WriteByte('%')
+			continue
+		case 'n':
+			literal.WriteByte('\n')
+			continue
+		case 't':
+			literal.WriteByte('\t')
+			continue
+		}
+
+		d, known := directives[byte(spec.conv)]
+		if !known || spec.conv >= 0x80 || !d.uts35OK || spec.upper {
+			return "", fmt.Errorf("tuesday: %%%c has no UTS #35 equivalent", spec.conv)
+		}
+		if spec.width >= 0 && spec.width != d.defaultWidth {
+			return "", fmt.Errorf("tuesday: %%%c with explicit width has no UTS #35 equivalent", spec.conv)
+		}
+		if spec.pad == padNone {
+			return "", fmt.Errorf("tuesday: %%%c with the %%- flag has no UTS #35 equivalent", spec.conv)
+		}
+		if spec.pad != 0 && spec.pad != d.defaultPad {
+			return "", fmt.Errorf("tuesday: %%%c with a padding flag has no UTS #35 equivalent", spec.conv)
+		}
+		flushLiteral()
+		b.WriteString(d.uts35)
+	}
+	flushLiteral()
+	return b.String(), nil
+}
+
+// needsQuoting reports whether s must be wrapped in single quotes to appear
+// literally in an LDML pattern. Plain punctuation (the common case: "-",
+// "/", " ", ":") has no meaning to LDML and can be left bare; ASCII letters
+// and apostrophes do, so any run containing one must be quoted.
+func needsQuoting(s string) bool {
+	for _, r := range s {
+		if r == '\'' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package tuesday
+
+import "testing"
+
+var uts35Tests = []struct {
+	format  string
+	pattern string
+	wantErr bool
+}{
+	{"%Y-%m-%dT%H:%M:%SZ", "yyyy-MM-dd'T'HH:mm:ss'Z'", false},
+	{"%a %b %d %T %z %Y", "E MMM dd HH:mm:ss Z yyyy", false},
+	{"%v", "d-MMM-yyyy", false},
+	{"%F", "yyyy-MM-dd", false},
+	{"%D", "MM/dd/yy", false},
+	{"%R", "HH:mm", false},
+	{"%T", "HH:mm:ss", false},
+	{"%X", "HH:mm:ss", false},
+	{"%r", "hh:mm:ss a", false},
+	{"%c", "E MMM d HH:mm:ss yyyy", false},
+	{"%+", "E MMM d HH:mm:ss zzz yyyy", false},
+
+	// no LDML equivalent.
+	{"%3N", "", true},
+	{"%U", "", true},
+	{"%s", "", true},
+	{"%::z", "", true},
+	{"%^B", "", true},
+}
+
+func TestToUTS35(t *testing.T) {
+	for _, test := range uts35Tests {
+		pattern, err := ToUTS35(test.format)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ToUTS35(%q) err = %v, wantErr %v", test.format, err, test.wantErr)
+			continue
+		}
+		if err == nil && pattern != test.pattern {
+			t.Errorf("ToUTS35(%q) = %q, want %q", test.format, pattern, test.pattern)
+		}
+	}
+}
+
+func TestToUTS35_quotesLiterals(t *testing.T) {
+	pattern, err := ToUTS35("%Y's %m")
+	if err != nil {
+		t.Fatalf("ToUTS35: %v", err)
+	}
+	const want = "yyyy'''s 'MM"
+	if pattern != want {
+		t.Errorf("ToUTS35 = %q, want %q", pattern, want)
+	}
+}